@@ -0,0 +1,47 @@
+// Package config defines the configuration accepted by the video
+// transcoding API and its providers.
+package config
+
+// Config is the top-level application configuration, holding the
+// per-provider configuration blocks.
+type Config struct {
+	MediaConvert       *MediaConvert
+	ElementalConductor ElementalConductor
+}
+
+// MediaConvert holds the configuration needed to talk to AWS Elemental
+// MediaConvert.
+type MediaConvert struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	Region          string
+	Role            string
+
+	// Endpoint is the account-specific MediaConvert endpoint to use. When
+	// set, it skips the DescribeEndpoints discovery call entirely, which is
+	// useful for tests and VPC endpoints.
+	Endpoint string
+
+	// SNSTopicARN, when set, makes every job publish periodic status
+	// updates to CloudWatch Events, for the provider/notifications handler
+	// to consume instead of being polled. The ARN itself is not attached to
+	// the job; routing those events to the topic is done by a CloudWatch
+	// Events rule that this provider does not create.
+	SNSTopicARN string
+}
+
+// ElementalConductor holds the configuration needed to talk to an Elemental
+// Conductor instance.
+type ElementalConductor struct {
+	Host            string
+	UserLogin       string
+	APIKey          string
+	AuthExpires     int
+	AccessKeyID     string
+	SecretAccessKey string
+	Destination     string
+
+	// NotificationURL, when set, is attached to every job so Elemental
+	// Conductor posts job state changes to it instead of being polled.
+	NotificationURL string
+}
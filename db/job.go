@@ -0,0 +1,59 @@
+package db
+
+import "time"
+
+// Job represents a single transcoding job, as submitted to a provider.
+type Job struct {
+	ID             string                 `json:"jobID,omitempty"`
+	ProviderName   string                 `json:"providerName,omitempty"`
+	ProviderJobID  string                 `json:"providerJobID,omitempty"`
+	Status         string                 `json:"status,omitempty"`
+	ProviderStatus map[string]interface{} `json:"providerStatus,omitempty"`
+	CreationTime   time.Time              `json:"creationTime,omitempty"`
+
+	// SourceMedia is the single input file for jobs that don't stitch
+	// multiple inputs together. Jobs that set Inputs use that list instead.
+	SourceMedia string `json:"sourceMedia,omitempty"`
+
+	// Inputs, when set, lists the clips to stitch into a single output, in
+	// order. Takes precedence over SourceMedia.
+	Inputs []TranscodeInput `json:"inputs,omitempty"`
+
+	Output          JobOutput       `json:"output"`
+	StreamingParams StreamingParams `json:"streamingParams,omitempty"`
+}
+
+// TranscodeInput is a single clip to stitch into a job's output. StartTime
+// and EndTime are provider-native timecodes (e.g. "00:00:10:00"); either or
+// both may be empty to leave that edge of the clip unbounded.
+type TranscodeInput struct {
+	SourceMedia string `json:"source"`
+	StartTime   string `json:"startTime,omitempty"`
+	EndTime     string `json:"endTime,omitempty"`
+}
+
+// JobOutput describes where a job's outputs are written and what they are.
+type JobOutput struct {
+	Destination string            `json:"destination,omitempty"`
+	Outputs     []TranscodeOutput `json:"outputs,omitempty"`
+}
+
+// TranscodeOutput is a single rendition of a job's output, encoded with
+// Preset and written to FileName (relative to JobOutput.Destination). Type
+// identifies which output group the rendition belongs to: "file" (the
+// default, when empty) for a plain file output, or a streaming protocol
+// name ("hls", "dash", "cmaf") matching the job's StreamingParams.Protocol
+// for a rendition that belongs to the adaptive bitrate ladder.
+type TranscodeOutput struct {
+	Preset   PresetMap `json:"preset,omitempty"`
+	FileName string    `json:"fileName,omitempty"`
+	Type     string    `json:"type,omitempty"`
+}
+
+// StreamingParams configures the adaptive bitrate packaging of a job's
+// streaming outputs.
+type StreamingParams struct {
+	Protocol         string `json:"protocol,omitempty"`
+	SegmentDuration  uint   `json:"segmentDuration,omitempty"`
+	PlaylistFileName string `json:"playlistFileName,omitempty"`
+}
@@ -0,0 +1,42 @@
+package db
+
+// Preset is the provider-neutral representation of an encoding preset. A
+// single Preset definition is translated into each provider's native
+// format by that provider's presetTranslator, so the same definition can be
+// stored once and used across providers.
+type Preset struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Container   string `json:"container"`
+	RateControl string `json:"rateControl,omitempty"`
+	TwoPass     bool   `json:"twoPass,omitempty"`
+	Video       VideoPreset `json:"video"`
+	Audio       AudioPreset `json:"audio"`
+}
+
+// VideoPreset holds the provider-neutral video encoding parameters of a
+// Preset.
+type VideoPreset struct {
+	Codec        string `json:"codec,omitempty"`
+	Profile      string `json:"profile,omitempty"`
+	ProfileLevel string `json:"profileLevel,omitempty"`
+	Bitrate      string `json:"bitrate,omitempty"`
+	GopSize      string `json:"gopSize,omitempty"`
+}
+
+// AudioPreset holds the provider-neutral audio encoding parameters of a
+// Preset.
+type AudioPreset struct {
+	Codec    string `json:"codec,omitempty"`
+	Bitrate  string `json:"bitrate,omitempty"`
+	Channels string `json:"channels,omitempty"`
+}
+
+// PresetMap associates a neutral preset Name with the provider-specific
+// preset ID each provider's CreatePreset returned when storing the
+// translated preset remotely. The API looks up presets by Name and lets
+// each provider pick its own ID out of ProviderMapping.
+type PresetMap struct {
+	Name            string            `json:"name"`
+	ProviderMapping map[string]string `json:"providerMapping"`
+}
@@ -0,0 +1,8 @@
+package db
+
+// Repository defines the persistence operations the API needs against its
+// job store.
+type Repository interface {
+	GetJobByProviderJobID(providerJobID string) (*Job, error)
+	SaveJob(job *Job) error
+}
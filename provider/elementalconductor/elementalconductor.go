@@ -21,7 +21,8 @@ import (
 	"strings"
 
 	"github.com/NYTimes/encoding-wrapper/elementalconductor"
-	"github.com/nytm/video-transcoding-api/config"
+	"github.com/NYTimes/video-transcoding-api/config"
+	"github.com/NYTimes/video-transcoding-api/db"
 	"github.com/nytm/video-transcoding-api/provider"
 )
 
@@ -40,8 +41,9 @@ func init() {
 }
 
 type elementalConductorProvider struct {
-	config *config.Config
-	client *elementalconductor.Client
+	config     *config.Config
+	client     *elementalconductor.Client
+	translator presetTranslator
 }
 
 func (p *elementalConductorProvider) TranscodeWithPresets(source string, presets []string) (*provider.JobStatus, error) {
@@ -165,6 +167,13 @@ func (p *elementalConductorProvider) newJob(source string, presets []string) *el
 		},
 		StreamAssembly: streamAssemblyList,
 	}
+	if notificationURL := p.config.ElementalConductor.NotificationURL; notificationURL != "" {
+		newJob.Notifications = elementalconductor.Notifications{
+			Notification: []elementalconductor.Notification{
+				{Type: "http", URL: notificationURL},
+			},
+		}
+	}
 	return &newJob
 }
 
@@ -182,5 +191,28 @@ func elementalConductorFactory(cfg *config.Config) (provider.TranscodingProvider
 		cfg.ElementalConductor.SecretAccessKey,
 		cfg.ElementalConductor.Destination,
 	)
-	return &elementalConductorProvider{client: client, config: cfg}, nil
+	return &elementalConductorProvider{client: client, config: cfg, translator: presetTranslator{}}, nil
+}
+
+// CreatePreset translates preset into its Elemental Conductor XML
+// representation and stores it remotely, returning the ID Elemental
+// Conductor assigned to it.
+func (p *elementalConductorProvider) CreatePreset(preset db.Preset) (string, error) {
+	elementalPreset, err := p.translator.translate(preset)
+	if err != nil {
+		return "", err
+	}
+	resp, err := p.client.CreatePreset(elementalPreset)
+	if err != nil {
+		return "", err
+	}
+	return resp.GetID(), nil
+}
+
+func (p *elementalConductorProvider) DeletePreset(presetID string) error {
+	return p.client.DeletePreset(presetID)
+}
+
+func (p *elementalConductorProvider) GetPreset(presetID string) (interface{}, error) {
+	return p.client.GetPreset(presetID)
 }
@@ -0,0 +1,60 @@
+package elementalconductor
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/NYTimes/encoding-wrapper/elementalconductor"
+	"github.com/NYTimes/video-transcoding-api/db"
+)
+
+// presetTranslator converts a provider-neutral db.Preset into the
+// Elemental Conductor preset body used by the Conductor preset APIs.
+type presetTranslator struct{}
+
+func (presetTranslator) translate(preset db.Preset) (elementalconductor.Preset, error) {
+	videoBitrate, err := strconv.Atoi(preset.Video.Bitrate)
+	if err != nil {
+		return elementalconductor.Preset{}, fmt.Errorf("invalid video bitrate %q: %s", preset.Video.Bitrate, err)
+	}
+	audioBitrate, err := strconv.Atoi(preset.Audio.Bitrate)
+	if err != nil {
+		return elementalconductor.Preset{}, fmt.Errorf("invalid audio bitrate %q: %s", preset.Audio.Bitrate, err)
+	}
+	return elementalconductor.Preset{
+		Name:        preset.Name,
+		Description: preset.Description,
+		Container:   containerFrom(preset.Container),
+		Video: elementalconductor.VideoPreset{
+			Codec:       codecFrom(preset.Video.Codec),
+			Profile:     strings.ToUpper(preset.Video.Profile),
+			Level:       preset.Video.ProfileLevel,
+			BitRate:     videoBitrate,
+			GopSize:     preset.Video.GopSize,
+			RateControl: strings.ToUpper(preset.RateControl),
+		},
+		Audio: elementalconductor.AudioPreset{
+			Codec:   strings.ToUpper(preset.Audio.Codec),
+			BitRate: audioBitrate,
+		},
+	}, nil
+}
+
+func codecFrom(codec string) string {
+	switch strings.ToLower(codec) {
+	case "h264":
+		return "H.264"
+	default:
+		return strings.ToUpper(codec)
+	}
+}
+
+func containerFrom(container string) string {
+	switch strings.ToLower(container) {
+	case "m3u8", "ts":
+		return "m3u8"
+	default:
+		return strings.ToLower(container)
+	}
+}
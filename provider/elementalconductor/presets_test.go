@@ -0,0 +1,62 @@
+package elementalconductor
+
+import (
+	"testing"
+
+	"github.com/NYTimes/video-transcoding-api/db"
+)
+
+func TestPresetTranslatorTranslate(t *testing.T) {
+	preset := db.Preset{
+		Name:        "preset-720p",
+		Description: "720p h264",
+		Container:   "m3u8",
+		RateControl: "vbr",
+		Video: db.VideoPreset{
+			Codec:        "h264",
+			Profile:      "high",
+			ProfileLevel: "4.1",
+			Bitrate:      "3500000",
+			GopSize:      "90",
+		},
+		Audio: db.AudioPreset{
+			Codec:   "aac",
+			Bitrate: "128000",
+		},
+	}
+	translated, err := presetTranslator{}.translate(preset)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if translated.Name != preset.Name {
+		t.Errorf("Name = %q, want %q", translated.Name, preset.Name)
+	}
+	if translated.Container != "m3u8" {
+		t.Errorf("Container = %q, want m3u8", translated.Container)
+	}
+	if translated.Video.Codec != "H.264" {
+		t.Errorf("Video.Codec = %q, want H.264", translated.Video.Codec)
+	}
+	if translated.Video.BitRate != 3500000 {
+		t.Errorf("Video.BitRate = %d, want 3500000", translated.Video.BitRate)
+	}
+	if translated.Video.RateControl != "VBR" {
+		t.Errorf("Video.RateControl = %q, want VBR", translated.Video.RateControl)
+	}
+	if translated.Audio.Codec != "AAC" {
+		t.Errorf("Audio.Codec = %q, want AAC", translated.Audio.Codec)
+	}
+	if translated.Audio.BitRate != 128000 {
+		t.Errorf("Audio.BitRate = %d, want 128000", translated.Audio.BitRate)
+	}
+}
+
+func TestPresetTranslatorTranslateInvalidBitrate(t *testing.T) {
+	preset := db.Preset{
+		Video: db.VideoPreset{Codec: "h264", Bitrate: "not-a-number"},
+		Audio: db.AudioPreset{Codec: "aac", Bitrate: "128000"},
+	}
+	if _, err := (presetTranslator{}).translate(preset); err == nil {
+		t.Fatal("expected an error for a non-numeric video bitrate")
+	}
+}
@@ -18,12 +18,8 @@ package mediaconvert
 import (
 	"errors"
 	"fmt"
-	"path/filepath"
 	"regexp"
-	"strconv"
-	"strings"
-	"time"
-	"encoding/json"
+	"sync"
 
 	"github.com/NYTimes/video-transcoding-api/config"
 	"github.com/NYTimes/video-transcoding-api/db"
@@ -54,25 +50,57 @@ func init() {
 }
 
 type awsProvider struct {
-	c      mediaconvertiface.MediaConvertAPI
-	config *config.MediaConvert
+	mu         sync.RWMutex
+	c          mediaconvertiface.MediaConvertAPI
+	session    *session.Session
+	config     *config.MediaConvert
+	translator presetTranslator
+	stop       chan struct{}
 }
 
-func (p *awsProvider) Transcode(job *db.Job) (*provider.JobStatus, error) {
+// client returns the current MediaConvert client, safe for concurrent use
+// with the background endpoint refresh started by mediaConvertFactory.
+func (p *awsProvider) client() mediaconvertiface.MediaConvertAPI {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.c
+}
 
-	svc := mediaconvert.New(mySession)
+func (p *awsProvider) setClient(c mediaconvertiface.MediaConvertAPI) {
+	p.mu.Lock()
+	p.c = c
+	p.mu.Unlock()
+}
 
-	var params *mediaconvert.CreateJobInput
-	err := json.Unmarshal([]byte(sampleJson), params)
+// Transcode creates a MediaConvert job from the outputs and presets
+// referenced by job, using the MediaConvert preset names stored in each
+// output's provider mapping.
+func (p *awsProvider) Transcode(job *db.Job) (*provider.JobStatus, error) {
+	outputGroups, err := p.outputGroupsFrom(job)
 	if err != nil {
-		return nil, err;
+		return nil, err
 	}
-
-	resp, err := svc.CreateJob(params)
+	inputs, err := mediaConvertInputsFrom(job)
 	if err != nil {
-		return nil, err;
+		return nil, err
+	}
+	input := &mediaconvert.CreateJobInput{
+		Role: aws.String(p.config.Role),
+		Settings: &mediaconvert.JobSettings{
+			OutputGroups: outputGroups,
+			Inputs:       inputs,
+		},
+	}
+	if p.config.SNSTopicARN != "" {
+		// Job state changes are published to CloudWatch Events at this
+		// cadence; a CloudWatch Events rule forwards them to the configured
+		// SNS topic, which the provider/notifications handler consumes.
+		input.StatusUpdateInterval = aws.String(mediaconvert.StatusUpdateIntervalSeconds60)
+	}
+	resp, err := p.client().CreateJob(input)
+	if err != nil {
+		return nil, fmt.Errorf("error creating MediaConvert job: %s", err)
 	}
-
 	return &provider.JobStatus{
 		ProviderName:  Name,
 		ProviderJobID: aws.StringValue(resp.Job.Id),
@@ -93,170 +121,122 @@ func mediaConvertFactory(cfg *config.Config) (provider.TranscodingProvider, erro
 	if err != nil {
 		return nil, err
 	}
-	return &awsProvider{
-		c:      mediaconvert.New(awsSession),
-		config: cfg.MediaConvert,
-	}, nil
+	p := &awsProvider{
+		session:    awsSession,
+		config:     cfg.MediaConvert,
+		translator: presetTranslator{},
+		stop:       make(chan struct{}),
+	}
+	endpoint := cfg.MediaConvert.Endpoint
+	if endpoint == "" {
+		endpoint, err = discoverEndpoint(mediaconvert.New(awsSession))
+		if err != nil {
+			return nil, fmt.Errorf("error discovering MediaConvert endpoint: %s", err)
+		}
+		go p.refreshEndpointPeriodically(endpointRefreshInterval)
+	}
+	p.setClient(clientWithEndpoint(awsSession, endpoint))
+	return p, nil
 }
 
 func (p *awsProvider) JobStatus(job *db.Job) (*provider.JobStatus, error) {
+	resp, err := p.client().GetJob(&mediaconvert.GetJobInput{Id: aws.String(job.ProviderJobID)})
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving MediaConvert job: %s", err)
+	}
+	providerStatus := map[string]interface{}{
+		"status": aws.StringValue(resp.Job.Status),
+	}
+	if resp.Job.Timing != nil {
+		if resp.Job.Timing.SubmitTime != nil {
+			providerStatus["submitted"] = *resp.Job.Timing.SubmitTime
+		}
+		if resp.Job.Timing.StartTime != nil {
+			providerStatus["start_time"] = *resp.Job.Timing.StartTime
+		}
+		if resp.Job.Timing.FinishTime != nil {
+			providerStatus["finish_time"] = *resp.Job.Timing.FinishTime
+		}
+	}
+	if resp.Job.ErrorMessage != nil {
+		providerStatus["error_message"] = *resp.Job.ErrorMessage
+	}
+	return &provider.JobStatus{
+		ProviderName:   Name,
+		ProviderJobID:  aws.StringValue(resp.Job.Id),
+		Status:         StatusMap(aws.StringValue(resp.Job.Status)),
+		ProviderStatus: providerStatus,
+	}, nil
+}
+
+// StatusMap translates a MediaConvert job status (as returned by GetJob or
+// carried in a job state change event) into a provider.Status. It's
+// exported so provider/notifications can map a job state notification the
+// same way JobStatus does, keeping the API-served and notification-cached
+// statuses in agreement.
+func StatusMap(mediaConvertStatus string) provider.Status {
+	switch mediaConvertStatus {
+	case mediaconvert.JobStatusSubmitted:
+		return provider.StatusQueued
+	case mediaconvert.JobStatusProgressing:
+		return provider.StatusStarted
+	case mediaconvert.JobStatusComplete:
+		return provider.StatusFinished
+	case mediaconvert.JobStatusCanceled:
+		return provider.StatusCanceled
+	case mediaconvert.JobStatusError:
+		return provider.StatusFailed
+	default:
+		return provider.StatusFailed
+	}
 }
 
 func (p *awsProvider) CancelJob(id string) error {
-	return nil
+	_, err := p.client().CancelJob(&mediaconvert.CancelJobInput{Id: aws.String(id)})
+	return err
 }
 
 func (p *awsProvider) Capabilities() provider.Capabilities {
 	return provider.Capabilities{
 		InputFormats:  []string{"h264"},
-		OutputFormats: []string{"mp4", "hls", "webm"},
+		OutputFormats: []string{"mp4", "hls", "webm", "dash", "cmaf"},
 		Destinations:  []string{"s3"},
 	}
 }
 
 func (p *awsProvider) CreatePreset(preset db.Preset) (string, error) {
+	settings, err := p.translator.translate(preset)
+	if err != nil {
+		return "", err
+	}
+	resp, err := p.client().CreatePreset(&mediaconvert.CreatePresetInput{
+		Name:        aws.String(preset.Name),
+		Description: aws.String(preset.Description),
+		Settings:    settings,
+	})
+	if err != nil {
+		return "", fmt.Errorf("error creating MediaConvert preset: %s", err)
+	}
+	return aws.StringValue(resp.Preset.Name), nil
 }
 
 func (p *awsProvider) DeletePreset(presetID string) error {
+	_, err := p.client().DeletePreset(&mediaconvert.DeletePresetInput{Name: aws.String(presetID)})
+	return err
 }
 
 func (p *awsProvider) GetPreset(presetID string) (interface{}, error) {
+	resp, err := p.client().GetPreset(&mediaconvert.GetPresetInput{Name: aws.String(presetID)})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Preset, nil
 }
 
 func (p *awsProvider) Healthcheck() error {
+	_, err := p.client().DescribeEndpoints(&mediaconvert.DescribeEndpointsInput{})
+	if err != nil {
+		return fmt.Errorf("error checking MediaConvert endpoints: %s", err)
+	}
+	return nil
 }
-
-var sampleJson = `{
-    "UserMetadata": {},
-    "Role": "ROLE ARN",
-    "Settings": {
-      "OutputGroups": [
-        {
-          "Name": "File Group",
-          "OutputGroupSettings": {
-            "Type": "FILE_GROUP_SETTINGS",
-            "FileGroupSettings": {
-              "Destination": "s3://bucket/out"
-            }
-          },
-          "Outputs": [
-            {
-              "VideoDescription": {
-                "ScalingBehavior": "DEFAULT",
-                "TimecodeInsertion": "DISABLED",
-                "AntiAlias": "ENABLED",
-                "Sharpness": 50,
-                "CodecSettings": {
-                  "Codec": "H_264",
-                  "H264Settings": {
-                    "InterlaceMode": "PROGRESSIVE",
-                    "NumberReferenceFrames": 3,
-                    "Syntax": "DEFAULT",
-                    "Softness": 0,
-                    "GopClosedCadence": 1,
-                    "GopSize": 48,
-                    "Slices": 1,
-                    "GopBReference": "DISABLED",
-                    "SlowPal": "DISABLED",
-                    "SpatialAdaptiveQuantization": "ENABLED",
-                    "TemporalAdaptiveQuantization": "ENABLED",
-                    "FlickerAdaptiveQuantization": "DISABLED",
-                    "EntropyEncoding": "CABAC",
-                    "Bitrate": 4500000,
-                    "FramerateControl": "SPECIFIED",
-                    "RateControlMode": "CBR",
-                    "CodecProfile": "HIGH",
-                    "Telecine": "NONE",
-                    "MinIInterval": 0,
-                    "AdaptiveQuantization": "HIGH",
-                    "CodecLevel": "LEVEL_4_1",
-                    "FieldEncoding": "PAFF",
-                    "SceneChangeDetect": "ENABLED",
-                    "QualityTuningLevel": "SINGLE_PASS_HQ",
-                    "FramerateConversionAlgorithm": "DUPLICATE_DROP",
-                    "UnregisteredSeiTimecode": "DISABLED",
-                    "GopSizeUnits": "FRAMES",
-                    "ParControl": "INITIALIZE_FROM_SOURCE",
-                    "NumberBFramesBetweenReferenceFrames": 3,
-                    "RepeatPps": "DISABLED",
-                    "HrdBufferSize": 9000000,
-                    "HrdBufferInitialFillPercentage": 90,
-                    "FramerateNumerator": 24000,
-                    "FramerateDenominator": 1001
-                  }
-                },
-                "AfdSignaling": "NONE",
-                "DropFrameTimecode": "ENABLED",
-                "RespondToAfd": "NONE",
-                "ColorMetadata": "INSERT",
-                "Width": 1920,
-                "Height": 1080
-              },
-              "AudioDescriptions": [
-                {
-                  "AudioTypeControl": "FOLLOW_INPUT",
-                  "CodecSettings": {
-                    "Codec": "AAC",
-                    "AacSettings": {
-                      "AudioDescriptionBroadcasterMix": "NORMAL",
-                      "Bitrate": 96000,
-                      "RateControlMode": "CBR",
-                      "CodecProfile": "LC",
-                      "CodingMode": "CODING_MODE_2_0",
-                      "RawFormat": "NONE",
-                      "SampleRate": 48000,
-                      "Specification": "MPEG4"
-                    }
-                  },
-                  "LanguageCodeControl": "FOLLOW_INPUT"
-                }
-              ],
-              "ContainerSettings": {
-                "Container": "MP4",
-                "Mp4Settings": {
-                  "CslgAtom": "INCLUDE",
-                  "FreeSpaceBox": "EXCLUDE",
-                  "MoovPlacement": "PROGRESSIVE_DOWNLOAD"
-                }
-              }
-            }
-          ]
-        }
-      ],
-      "AdAvailOffset": 0,
-      "Inputs": [
-        {
-          "AudioSelectors": {
-            "Audio Selector 1": {
-              "Tracks": [
-                1
-              ],
-              "Offset": 0,
-              "DefaultSelection": "DEFAULT",
-              "SelectorType": "TRACK",
-              "ProgramSelection": 1
-            },
-            "Audio Selector 2": {
-              "Tracks": [
-                2
-              ],
-              "Offset": 0,
-              "DefaultSelection": "NOT_DEFAULT",
-              "SelectorType": "TRACK",
-              "ProgramSelection": 1
-            }
-          },
-          "VideoSelector": {
-            "ColorSpace": "FOLLOW"
-          },
-          "FilterEnable": "AUTO",
-          "PsiControl": "USE_PSI",
-          "FilterStrength": 0,
-          "DeblockFilter": "DISABLED",
-          "DenoiseFilter": "DISABLED",
-          "TimecodeSource": "EMBEDDED",
-          "FileInput": "s3://input"
-        }
-      ]
-    }
-  }`
\ No newline at end of file
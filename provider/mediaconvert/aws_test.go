@@ -0,0 +1,44 @@
+package mediaconvert
+
+import (
+	"testing"
+
+	"github.com/NYTimes/video-transcoding-api/provider"
+	"github.com/aws/aws-sdk-go/service/mediaconvert"
+)
+
+func TestStatusMap(t *testing.T) {
+	tests := []struct {
+		mediaConvertStatus string
+		expected           provider.Status
+	}{
+		{mediaconvert.JobStatusSubmitted, provider.StatusQueued},
+		{mediaconvert.JobStatusProgressing, provider.StatusStarted},
+		{mediaconvert.JobStatusComplete, provider.StatusFinished},
+		{mediaconvert.JobStatusCanceled, provider.StatusCanceled},
+		{mediaconvert.JobStatusError, provider.StatusFailed},
+		{"SOMETHING_UNKNOWN", provider.StatusFailed},
+	}
+	for _, test := range tests {
+		if got := StatusMap(test.mediaConvertStatus); got != test.expected {
+			t.Errorf("StatusMap(%q) = %q, want %q", test.mediaConvertStatus, got, test.expected)
+		}
+	}
+}
+
+func TestCapabilities(t *testing.T) {
+	p := &awsProvider{}
+	capabilities := p.Capabilities()
+	if len(capabilities.OutputFormats) == 0 {
+		t.Fatal("expected at least one output format")
+	}
+	formats := map[string]bool{}
+	for _, format := range capabilities.OutputFormats {
+		formats[format] = true
+	}
+	for _, want := range []string{"mp4", "hls", "dash", "cmaf"} {
+		if !formats[want] {
+			t.Errorf("expected output format %q to be supported", want)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package mediaconvert
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/mediaconvert"
+	"github.com/aws/aws-sdk-go/service/mediaconvert/mediaconvertiface"
+)
+
+// endpointRefreshInterval is how often mediaConvertFactory re-discovers the
+// account-specific MediaConvert endpoint in the background.
+const endpointRefreshInterval = time.Hour
+
+// discoverEndpoint calls DescribeEndpoints through client (the
+// default-endpoint client at construction, the current account-specific
+// client on a background refresh) to learn the account-specific URL that
+// must be used for every other call. MediaConvert requires this account-specific endpoint for
+// every request, so an empty result is treated as a discovery failure
+// rather than silently falling back to the default endpoint. client is
+// taken as an interface, rather than built from a session internally, so
+// discovery can be exercised against a fake in tests.
+func discoverEndpoint(client mediaconvertiface.MediaConvertAPI) (string, error) {
+	resp, err := client.DescribeEndpoints(&mediaconvert.DescribeEndpointsInput{})
+	if err != nil {
+		return "", err
+	}
+	if len(resp.Endpoints) == 0 {
+		return "", fmt.Errorf("MediaConvert DescribeEndpoints returned no endpoints")
+	}
+	return aws.StringValue(resp.Endpoints[0].Url), nil
+}
+
+// clientWithEndpoint builds a MediaConvert client bound to endpoint, which
+// must be non-empty.
+func clientWithEndpoint(awsSession *session.Session, endpoint string) *mediaconvert.MediaConvert {
+	return mediaconvert.New(awsSession, aws.NewConfig().WithEndpoint(endpoint))
+}
+
+// refreshEndpointPeriodically re-runs endpoint discovery every interval and
+// swaps in a client bound to the newly discovered endpoint, until Close
+// stops it. A failed discovery is treated as transient: it's ignored and
+// the client keeps using the last known-good endpoint.
+func (p *awsProvider) refreshEndpointPeriodically(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.stop:
+			return
+		case <-ticker.C:
+			endpoint, err := discoverEndpoint(p.client())
+			if err != nil {
+				continue
+			}
+			p.setClient(clientWithEndpoint(p.session, endpoint))
+		}
+	}
+}
+
+// Close stops the background endpoint-refresh goroutine started by
+// mediaConvertFactory. It is safe to call at most once.
+func (p *awsProvider) Close() error {
+	close(p.stop)
+	return nil
+}
@@ -0,0 +1,71 @@
+package mediaconvert
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/mediaconvert"
+)
+
+func TestDiscoverEndpoint(t *testing.T) {
+	client := &fakeMediaConvertClient{
+		describeEndpointsFn: func(*mediaconvert.DescribeEndpointsInput) (*mediaconvert.DescribeEndpointsOutput, error) {
+			return &mediaconvert.DescribeEndpointsOutput{
+				Endpoints: []*mediaconvert.Endpoint{{Url: aws.String("https://abcd1234.mediaconvert.us-east-1.amazonaws.com")}},
+			}, nil
+		},
+	}
+	endpoint, err := discoverEndpoint(client)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if endpoint != "https://abcd1234.mediaconvert.us-east-1.amazonaws.com" {
+		t.Errorf("endpoint = %q, want the discovered URL", endpoint)
+	}
+}
+
+func TestDiscoverEndpointNoEndpoints(t *testing.T) {
+	client := &fakeMediaConvertClient{
+		describeEndpointsFn: func(*mediaconvert.DescribeEndpointsInput) (*mediaconvert.DescribeEndpointsOutput, error) {
+			return &mediaconvert.DescribeEndpointsOutput{}, nil
+		},
+	}
+	if _, err := discoverEndpoint(client); err == nil {
+		t.Fatal("expected an error when DescribeEndpoints returns no endpoints, to avoid a silent fallback to the default endpoint")
+	}
+}
+
+func TestDiscoverEndpointAPIError(t *testing.T) {
+	client := &fakeMediaConvertClient{
+		describeEndpointsFn: func(*mediaconvert.DescribeEndpointsInput) (*mediaconvert.DescribeEndpointsOutput, error) {
+			return nil, errors.New("boom")
+		},
+	}
+	if _, err := discoverEndpoint(client); err == nil {
+		t.Fatal("expected the underlying API error to be propagated")
+	}
+}
+
+func TestRefreshEndpointPeriodicallyStopsOnClose(t *testing.T) {
+	p := &awsProvider{stop: make(chan struct{})}
+	p.setClient(&fakeMediaConvertClient{
+		describeEndpointsFn: func(*mediaconvert.DescribeEndpointsInput) (*mediaconvert.DescribeEndpointsOutput, error) {
+			return &mediaconvert.DescribeEndpointsOutput{}, nil
+		},
+	})
+	done := make(chan struct{})
+	go func() {
+		p.refreshEndpointPeriodically(time.Millisecond)
+		close(done)
+	}()
+	if err := p.Close(); err != nil {
+		t.Fatalf("unexpected error closing provider: %s", err)
+	}
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("refreshEndpointPeriodically did not stop after Close")
+	}
+}
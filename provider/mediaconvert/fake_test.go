@@ -0,0 +1,20 @@
+package mediaconvert
+
+import (
+	"github.com/aws/aws-sdk-go/service/mediaconvert"
+	"github.com/aws/aws-sdk-go/service/mediaconvert/mediaconvertiface"
+)
+
+// fakeMediaConvertClient is a mediaconvertiface.MediaConvertAPI whose
+// methods are backed by per-call function fields, so tests only need to
+// stub the calls they exercise. Embedding the interface satisfies the
+// methods a test doesn't override, panicking if they're called.
+type fakeMediaConvertClient struct {
+	mediaconvertiface.MediaConvertAPI
+
+	describeEndpointsFn func(*mediaconvert.DescribeEndpointsInput) (*mediaconvert.DescribeEndpointsOutput, error)
+}
+
+func (f *fakeMediaConvertClient) DescribeEndpoints(in *mediaconvert.DescribeEndpointsInput) (*mediaconvert.DescribeEndpointsOutput, error) {
+	return f.describeEndpointsFn(in)
+}
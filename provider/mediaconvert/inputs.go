@@ -0,0 +1,91 @@
+package mediaconvert
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/NYTimes/video-transcoding-api/db"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/mediaconvert"
+)
+
+// mediaConvertInputsFrom builds the list of MediaConvert inputs for job. A
+// job with multiple db.TranscodeInput entries is stitched into a single
+// output by feeding them as consecutive Inputs, each with its own
+// InputClippings when a clip's start or end timecode is set. A job with no
+// Inputs falls back to the single SourceMedia file, unclipped, for
+// backwards compatibility.
+func mediaConvertInputsFrom(job *db.Job) ([]*mediaconvert.Input, error) {
+	if len(job.Inputs) == 0 {
+		if err := validateSourceScheme(job.SourceMedia); err != nil {
+			return nil, err
+		}
+		return []*mediaconvert.Input{
+			{
+				FileInput:      aws.String(job.SourceMedia),
+				TimecodeSource: aws.String(mediaconvert.InputTimecodeSourceEmbedded),
+			},
+		}, nil
+	}
+	if err := validateContainerConsistency(job.Inputs); err != nil {
+		return nil, err
+	}
+	inputs := make([]*mediaconvert.Input, 0, len(job.Inputs))
+	for _, clip := range job.Inputs {
+		mcInput := &mediaconvert.Input{
+			FileInput:      aws.String(clip.SourceMedia),
+			TimecodeSource: aws.String(mediaconvert.InputTimecodeSourceSpecifiedstart),
+		}
+		if clip.StartTime != "" || clip.EndTime != "" {
+			clipping := &mediaconvert.InputClipping{}
+			if clip.StartTime != "" {
+				clipping.StartTimecode = aws.String(clip.StartTime)
+			}
+			if clip.EndTime != "" {
+				clipping.EndTimecode = aws.String(clip.EndTime)
+			}
+			mcInput.InputClippings = []*mediaconvert.InputClipping{clipping}
+		}
+		inputs = append(inputs, mcInput)
+	}
+	return inputs, nil
+}
+
+// validateSourceScheme ensures source points at an S3 object, the only
+// input scheme MediaConvert supports.
+func validateSourceScheme(source string) error {
+	if !s3Pattern.MatchString(source) {
+		return fmt.Errorf("invalid input %q: MediaConvert only supports s3:// sources", source)
+	}
+	return nil
+}
+
+// validateContainerConsistency ensures every clip points at an S3 source
+// and that all clips being stitched together share the same container
+// extension.
+//
+// This is deliberately narrower than verifying the clips' audio/video
+// track configurations are compatible (e.g. matching channel layouts and
+// codecs): doing that would require probing each source's media info,
+// which this provider does not do. A same-extension pair can still have
+// incompatible tracks (stereo vs. 5.1), and a compatible pair can use
+// different extensions (.mp4 vs. .mov), so this check only catches an
+// obvious container mismatch and surfaces it as a clear, separate error
+// from an actual MediaConvert rejection; it is not a track-layout check.
+func validateContainerConsistency(inputs []db.TranscodeInput) error {
+	var extension string
+	for i, clip := range inputs {
+		if err := validateSourceScheme(clip.SourceMedia); err != nil {
+			return err
+		}
+		ext := filepath.Ext(clip.SourceMedia)
+		if i == 0 {
+			extension = ext
+			continue
+		}
+		if ext != extension {
+			return fmt.Errorf("mismatched container across inputs: %q does not share the container (%s) of the preceding inputs", clip.SourceMedia, extension)
+		}
+	}
+	return nil
+}
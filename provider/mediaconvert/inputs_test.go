@@ -0,0 +1,94 @@
+package mediaconvert
+
+import (
+	"testing"
+
+	"github.com/NYTimes/video-transcoding-api/db"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/mediaconvert"
+)
+
+func TestMediaConvertInputsFromSingleSource(t *testing.T) {
+	job := &db.Job{SourceMedia: "s3://bucket/source.mp4"}
+	inputs, err := mediaConvertInputsFrom(job)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(inputs) != 1 {
+		t.Fatalf("expected 1 input, got %d", len(inputs))
+	}
+	if aws.StringValue(inputs[0].FileInput) != job.SourceMedia {
+		t.Errorf("FileInput = %q, want %q", aws.StringValue(inputs[0].FileInput), job.SourceMedia)
+	}
+	if aws.StringValue(inputs[0].TimecodeSource) != mediaconvert.InputTimecodeSourceEmbedded {
+		t.Errorf("TimecodeSource = %q, want EMBEDDED for a single unclipped source", aws.StringValue(inputs[0].TimecodeSource))
+	}
+}
+
+func TestMediaConvertInputsFromRejectsNonS3SingleSource(t *testing.T) {
+	job := &db.Job{SourceMedia: "/local/source.mp4"}
+	if _, err := mediaConvertInputsFrom(job); err == nil {
+		t.Fatal("expected an error for a non-s3 SourceMedia")
+	}
+}
+
+func TestMediaConvertInputsFromStitchesMultipleClips(t *testing.T) {
+	job := &db.Job{
+		Inputs: []db.TranscodeInput{
+			{SourceMedia: "s3://bucket/part1.mp4", StartTime: "00:00:10:00", EndTime: "00:00:20:00"},
+			{SourceMedia: "s3://bucket/part2.mp4"},
+		},
+	}
+	inputs, err := mediaConvertInputsFrom(job)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(inputs) != 2 {
+		t.Fatalf("expected 2 inputs, got %d", len(inputs))
+	}
+	if aws.StringValue(inputs[0].TimecodeSource) != mediaconvert.InputTimecodeSourceSpecifiedstart {
+		t.Errorf("TimecodeSource = %q, want SPECIFIEDSTART for a stitched clip", aws.StringValue(inputs[0].TimecodeSource))
+	}
+	if len(inputs[0].InputClippings) != 1 {
+		t.Fatalf("expected 1 clipping on the first clip, got %d", len(inputs[0].InputClippings))
+	}
+	clipping := inputs[0].InputClippings[0]
+	if aws.StringValue(clipping.StartTimecode) != "00:00:10:00" || aws.StringValue(clipping.EndTimecode) != "00:00:20:00" {
+		t.Errorf("unexpected clipping: %+v", clipping)
+	}
+	if len(inputs[1].InputClippings) != 0 {
+		t.Errorf("expected no clipping on an input with no start/end time, got %d", len(inputs[1].InputClippings))
+	}
+}
+
+func TestMediaConvertInputsFromRejectsNonS3Clip(t *testing.T) {
+	job := &db.Job{
+		Inputs: []db.TranscodeInput{
+			{SourceMedia: "s3://bucket/part1.mp4"},
+			{SourceMedia: "/local/part2.mp4"},
+		},
+	}
+	if _, err := mediaConvertInputsFrom(job); err == nil {
+		t.Fatal("expected an error for a clip that isn't an s3 source")
+	}
+}
+
+func TestValidateContainerConsistencyRejectsMismatchedContainer(t *testing.T) {
+	inputs := []db.TranscodeInput{
+		{SourceMedia: "s3://bucket/part1.mp4"},
+		{SourceMedia: "s3://bucket/part2.mov"},
+	}
+	if err := validateContainerConsistency(inputs); err == nil {
+		t.Fatal("expected an error for clips with mismatched containers")
+	}
+}
+
+func TestValidateContainerConsistencyAcceptsMatchingContainer(t *testing.T) {
+	inputs := []db.TranscodeInput{
+		{SourceMedia: "s3://bucket/part1.mp4"},
+		{SourceMedia: "s3://bucket/part2.mp4"},
+	}
+	if err := validateContainerConsistency(inputs); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
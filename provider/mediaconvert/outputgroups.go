@@ -0,0 +1,153 @@
+package mediaconvert
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/NYTimes/video-transcoding-api/db"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/mediaconvert"
+)
+
+// outputGroupsFrom builds the MediaConvert output groups for job. Outputs
+// are routed by their explicit db.TranscodeOutput.Type: untyped ("file")
+// outputs are placed in a single FILE_GROUP_SETTINGS group, and outputs
+// typed for the job's configured streaming protocol are placed in a single
+// adaptive streaming group (HLS, DASH, or CMAF). A job may emit both groups
+// at once, e.g. mp4 thumbnails alongside HLS renditions; the container
+// extension of a rendition (which may be .mp4 for fMP4/CMAF segments) plays
+// no part in the decision.
+func (p *awsProvider) outputGroupsFrom(job *db.Job) ([]*mediaconvert.OutputGroup, error) {
+	if !s3Pattern.MatchString(job.Output.Destination) {
+		return nil, fmt.Errorf("invalid destination %q: MediaConvert only supports s3:// destinations", job.Output.Destination)
+	}
+	var fileOutputs, abrOutputs []db.TranscodeOutput
+	for _, output := range job.Output.Outputs {
+		outputType := output.Type
+		if outputType == "" {
+			outputType = "file"
+		}
+		if outputType == "file" {
+			fileOutputs = append(fileOutputs, output)
+			continue
+		}
+		if outputType != job.StreamingParams.Protocol {
+			return nil, fmt.Errorf("output %q is typed %q, which does not match the job's configured streaming protocol %q", output.FileName, outputType, job.StreamingParams.Protocol)
+		}
+		abrOutputs = append(abrOutputs, output)
+	}
+	var groups []*mediaconvert.OutputGroup
+	if len(fileOutputs) > 0 {
+		outputs, err := p.mediaConvertOutputsFrom(fileOutputs)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, &mediaconvert.OutputGroup{
+			Name: aws.String("File Group"),
+			OutputGroupSettings: &mediaconvert.OutputGroupSettings{
+				Type: aws.String(mediaconvert.OutputGroupTypeFileGroupSettings),
+				FileGroupSettings: &mediaconvert.FileGroupSettings{
+					Destination: aws.String(job.Output.Destination),
+				},
+			},
+			Outputs: outputs,
+		})
+	}
+	if len(abrOutputs) > 0 {
+		group, err := p.abrOutputGroupFrom(job, abrOutputs)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, group)
+	}
+	return groups, nil
+}
+
+// mediaConvertOutputsFrom builds one mediaconvert.Output per transcode
+// output, referencing the MediaConvert preset name stored in its provider
+// mapping. NameModifier is a suffix MediaConvert appends to the preset's
+// own base output name (it is not the output's file name), so it's derived
+// from the output's file name with its extension stripped.
+func (p *awsProvider) mediaConvertOutputsFrom(outputs []db.TranscodeOutput) ([]*mediaconvert.Output, error) {
+	result := make([]*mediaconvert.Output, 0, len(outputs))
+	for _, output := range outputs {
+		presetName, ok := output.Preset.ProviderMapping[Name]
+		if !ok {
+			return nil, fmt.Errorf("preset %q is not mapped to a MediaConvert preset", output.Preset.Name)
+		}
+		result = append(result, &mediaconvert.Output{
+			Preset:       aws.String(presetName),
+			NameModifier: aws.String(nameModifierFrom(output.FileName)),
+		})
+	}
+	return result, nil
+}
+
+// nameModifierFrom derives a MediaConvert output NameModifier from a
+// rendition's intended file name by stripping its directory and extension,
+// e.g. "renditions/video_720p.mp4" becomes "_video_720p".
+func nameModifierFrom(fileName string) string {
+	base := filepath.Base(fileName)
+	return "_" + strings.TrimSuffix(base, filepath.Ext(base))
+}
+
+// abrOutputGroupFrom builds the adaptive bitrate output group for job,
+// sharing the rendition ladder in abrOutputs across an HLS, DASH, or CMAF
+// output group according to job.StreamingParams.Protocol.
+func (p *awsProvider) abrOutputGroupFrom(job *db.Job, abrOutputs []db.TranscodeOutput) (*mediaconvert.OutputGroup, error) {
+	outputs, err := p.mediaConvertOutputsFrom(abrOutputs)
+	if err != nil {
+		return nil, err
+	}
+	// MediaConvert treats an adaptive streaming group's Destination as an
+	// S3 prefix: its last path element becomes the manifest base name, to
+	// which MediaConvert appends its own extension (.m3u8, .mpd, ...). So
+	// the playlist file name is joined as a directory prefix with its
+	// extension stripped, not concatenated onto the destination directory.
+	manifestBaseName := strings.TrimSuffix(job.StreamingParams.PlaylistFileName, filepath.Ext(job.StreamingParams.PlaylistFileName))
+	destination := aws.String(strings.TrimRight(job.Output.Destination, "/") + "/" + manifestBaseName)
+	segmentLength := aws.Int64(int64(job.StreamingParams.SegmentDuration))
+	switch job.StreamingParams.Protocol {
+	case "hls":
+		return &mediaconvert.OutputGroup{
+			Name: aws.String("HLS Group"),
+			OutputGroupSettings: &mediaconvert.OutputGroupSettings{
+				Type: aws.String(mediaconvert.OutputGroupTypeHlsGroupSettings),
+				HlsGroupSettings: &mediaconvert.HlsGroupSettings{
+					Destination:   destination,
+					SegmentLength: segmentLength,
+				},
+			},
+			Outputs: outputs,
+		}, nil
+	case "dash":
+		return &mediaconvert.OutputGroup{
+			Name: aws.String("DASH Group"),
+			OutputGroupSettings: &mediaconvert.OutputGroupSettings{
+				Type: aws.String(mediaconvert.OutputGroupTypeDashIsoGroupSettings),
+				DashIsoGroupSettings: &mediaconvert.DashIsoGroupSettings{
+					Destination:    destination,
+					SegmentLength:  segmentLength,
+					FragmentLength: segmentLength,
+				},
+			},
+			Outputs: outputs,
+		}, nil
+	case "cmaf":
+		return &mediaconvert.OutputGroup{
+			Name: aws.String("CMAF Group"),
+			OutputGroupSettings: &mediaconvert.OutputGroupSettings{
+				Type: aws.String(mediaconvert.OutputGroupTypeCmafGroupSettings),
+				CmafGroupSettings: &mediaconvert.CmafGroupSettings{
+					Destination:    destination,
+					SegmentLength:  segmentLength,
+					FragmentLength: segmentLength,
+				},
+			},
+			Outputs: outputs,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported streaming protocol %q", job.StreamingParams.Protocol)
+	}
+}
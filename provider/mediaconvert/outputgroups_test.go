@@ -0,0 +1,122 @@
+package mediaconvert
+
+import (
+	"testing"
+
+	"github.com/NYTimes/video-transcoding-api/db"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/mediaconvert"
+)
+
+func TestNameModifierFrom(t *testing.T) {
+	tests := []struct {
+		fileName string
+		expected string
+	}{
+		{"video_720p.mp4", "_video_720p"},
+		{"renditions/video_720p.mp4", "_video_720p"},
+		{"thumb.jpg", "_thumb"},
+	}
+	for _, test := range tests {
+		if got := nameModifierFrom(test.fileName); got != test.expected {
+			t.Errorf("nameModifierFrom(%q) = %q, want %q", test.fileName, got, test.expected)
+		}
+	}
+}
+
+func presetOutput(fileName, outputType string) db.TranscodeOutput {
+	return db.TranscodeOutput{
+		FileName: fileName,
+		Type:     outputType,
+		Preset: db.PresetMap{
+			Name:            fileName,
+			ProviderMapping: map[string]string{Name: "preset-arn"},
+		},
+	}
+}
+
+func TestOutputGroupsFromClassifiesByType(t *testing.T) {
+	job := &db.Job{
+		Output: db.JobOutput{
+			Destination: "s3://bucket/path",
+			Outputs: []db.TranscodeOutput{
+				presetOutput("thumb.mp4", ""),
+				presetOutput("video_720p.mp4", "hls"),
+			},
+		},
+		StreamingParams: db.StreamingParams{
+			Protocol:         "hls",
+			PlaylistFileName: "index.m3u8",
+		},
+	}
+	p := &awsProvider{}
+	groups, err := p.outputGroupsFrom(job)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(groups) != 2 {
+		t.Fatalf("expected 2 output groups (file + hls), got %d", len(groups))
+	}
+	var sawFile, sawHLS bool
+	for _, group := range groups {
+		switch aws.StringValue(group.OutputGroupSettings.Type) {
+		case mediaconvert.OutputGroupTypeFileGroupSettings:
+			sawFile = true
+			if len(group.Outputs) != 1 {
+				t.Errorf("expected 1 output in file group, got %d", len(group.Outputs))
+			}
+		case mediaconvert.OutputGroupTypeHlsGroupSettings:
+			sawHLS = true
+			if len(group.Outputs) != 1 {
+				t.Errorf("expected 1 output in hls group, got %d", len(group.Outputs))
+			}
+		}
+	}
+	if !sawFile || !sawHLS {
+		t.Fatalf("expected both a file group and an hls group, got %+v", groups)
+	}
+}
+
+func TestOutputGroupsFromRejectsMismatchedType(t *testing.T) {
+	job := &db.Job{
+		Output: db.JobOutput{
+			Destination: "s3://bucket/path",
+			Outputs: []db.TranscodeOutput{
+				presetOutput("video_720p.mp4", "dash"),
+			},
+		},
+		StreamingParams: db.StreamingParams{Protocol: "hls"},
+	}
+	p := &awsProvider{}
+	if _, err := p.outputGroupsFrom(job); err == nil {
+		t.Fatal("expected an error for an output typed for a protocol other than the job's")
+	}
+}
+
+func TestOutputGroupsFromRejectsNonS3Destination(t *testing.T) {
+	job := &db.Job{Output: db.JobOutput{Destination: "/local/path"}}
+	p := &awsProvider{}
+	if _, err := p.outputGroupsFrom(job); err == nil {
+		t.Fatal("expected an error for a non-s3 destination")
+	}
+}
+
+func TestAbrOutputGroupFromDestination(t *testing.T) {
+	job := &db.Job{
+		Output: db.JobOutput{Destination: "s3://bucket/path/"},
+		StreamingParams: db.StreamingParams{
+			Protocol:         "hls",
+			PlaylistFileName: "index.m3u8",
+			SegmentDuration:  6,
+		},
+	}
+	p := &awsProvider{}
+	group, err := p.abrOutputGroupFrom(job, []db.TranscodeOutput{presetOutput("video_720p.mp4", "hls")})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	destination := aws.StringValue(group.OutputGroupSettings.HlsGroupSettings.Destination)
+	if destination != "s3://bucket/path/index" {
+		t.Errorf("destination = %q, want %q", destination, "s3://bucket/path/index")
+	}
+}
@@ -0,0 +1,179 @@
+package mediaconvert
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/NYTimes/video-transcoding-api/db"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/mediaconvert"
+)
+
+// presetTranslator converts a provider-neutral db.Preset into the
+// MediaConvert PresetSettings understood by CreatePresetInput.
+type presetTranslator struct{}
+
+func (presetTranslator) translate(preset db.Preset) (*mediaconvert.PresetSettings, error) {
+	videoDescription, err := videoDescriptionFrom(preset)
+	if err != nil {
+		return nil, err
+	}
+	audioDescription, err := audioDescriptionFrom(preset)
+	if err != nil {
+		return nil, err
+	}
+	containerSettings, err := containerSettingsFrom(preset)
+	if err != nil {
+		return nil, err
+	}
+	return &mediaconvert.PresetSettings{
+		VideoDescription:  videoDescription,
+		AudioDescriptions: []*mediaconvert.AudioDescription{audioDescription},
+		ContainerSettings: containerSettings,
+	}, nil
+}
+
+func videoDescriptionFrom(preset db.Preset) (*mediaconvert.VideoDescription, error) {
+	if strings.ToLower(preset.Video.Codec) != "h264" {
+		return nil, fmt.Errorf("unsupported video codec %q: mediaconvert provider only supports h264", preset.Video.Codec)
+	}
+	bitrate, err := strconv.ParseInt(preset.Video.Bitrate, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid video bitrate %q: %s", preset.Video.Bitrate, err)
+	}
+	h264Settings := &mediaconvert.H264Settings{
+		Bitrate:            aws.Int64(bitrate),
+		RateControlMode:    aws.String(rateControlModeFrom(preset.RateControl)),
+		CodecProfile:       aws.String(codecProfileFrom(preset.Video.Profile)),
+		CodecLevel:         aws.String(codecLevelFrom(preset.Video.ProfileLevel)),
+		QualityTuningLevel: aws.String(mediaconvert.H264QualityTuningLevelSinglePassHq),
+	}
+	if preset.TwoPass {
+		h264Settings.QualityTuningLevel = aws.String(mediaconvert.H264QualityTuningLevelMultiPassHq)
+	}
+	if preset.Video.GopSize != "" {
+		gopSize, err := strconv.ParseFloat(preset.Video.GopSize, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid gop size %q: %s", preset.Video.GopSize, err)
+		}
+		h264Settings.GopSize = aws.Float64(gopSize)
+	}
+	return &mediaconvert.VideoDescription{
+		CodecSettings: &mediaconvert.VideoCodecSettings{
+			Codec:        aws.String(mediaconvert.VideoCodecH264),
+			H264Settings: h264Settings,
+		},
+	}, nil
+}
+
+func audioDescriptionFrom(preset db.Preset) (*mediaconvert.AudioDescription, error) {
+	if strings.ToLower(preset.Audio.Codec) != "aac" {
+		return nil, fmt.Errorf("unsupported audio codec %q: mediaconvert provider only supports aac", preset.Audio.Codec)
+	}
+	bitrate, err := strconv.ParseInt(preset.Audio.Bitrate, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid audio bitrate %q: %s", preset.Audio.Bitrate, err)
+	}
+	return &mediaconvert.AudioDescription{
+		CodecSettings: &mediaconvert.AudioCodecSettings{
+			Codec: aws.String(mediaconvert.AudioCodecAac),
+			AacSettings: &mediaconvert.AacSettings{
+				Bitrate:    aws.Int64(bitrate),
+				CodingMode: aws.String(codingModeFrom(preset.Audio.Channels)),
+				SampleRate: aws.Int64(48000),
+			},
+		},
+	}, nil
+}
+
+func containerSettingsFrom(preset db.Preset) (*mediaconvert.ContainerSettings, error) {
+	switch strings.ToLower(preset.Container) {
+	case "mp4":
+		return &mediaconvert.ContainerSettings{Container: aws.String(mediaconvert.ContainerTypeMp4)}, nil
+	case "m3u8", "ts":
+		return &mediaconvert.ContainerSettings{Container: aws.String(mediaconvert.ContainerTypeM3u8)}, nil
+	case "cmfc", "fmp4":
+		return &mediaconvert.ContainerSettings{Container: aws.String(mediaconvert.ContainerTypeCmfc)}, nil
+	default:
+		return nil, fmt.Errorf("unsupported container %q", preset.Container)
+	}
+}
+
+func rateControlModeFrom(rateControl string) string {
+	switch strings.ToLower(rateControl) {
+	case "vbr":
+		return mediaconvert.H264RateControlModeVbr
+	case "qvbr":
+		return mediaconvert.H264RateControlModeQvbr
+	default:
+		return mediaconvert.H264RateControlModeCbr
+	}
+}
+
+func codecProfileFrom(profile string) string {
+	switch strings.ToLower(profile) {
+	case "baseline":
+		return mediaconvert.H264CodecProfileBaseline
+	case "main":
+		return mediaconvert.H264CodecProfileMain
+	default:
+		return mediaconvert.H264CodecProfileHigh
+	}
+}
+
+// codecLevelFrom translates a profile level such as "4.1" into the
+// mediaconvert level constant "LEVEL_4_1". An empty or unrecognized level
+// falls back to "AUTO", rather than string-building a CodecLevel value
+// MediaConvert doesn't recognize.
+func codecLevelFrom(level string) string {
+	switch level {
+	case "1":
+		return mediaconvert.H264CodecLevelLevel1
+	case "1.1":
+		return mediaconvert.H264CodecLevelLevel1_1
+	case "1.2":
+		return mediaconvert.H264CodecLevelLevel1_2
+	case "1.3":
+		return mediaconvert.H264CodecLevelLevel1_3
+	case "2":
+		return mediaconvert.H264CodecLevelLevel2
+	case "2.1":
+		return mediaconvert.H264CodecLevelLevel2_1
+	case "2.2":
+		return mediaconvert.H264CodecLevelLevel2_2
+	case "3":
+		return mediaconvert.H264CodecLevelLevel3
+	case "3.1":
+		return mediaconvert.H264CodecLevelLevel3_1
+	case "3.2":
+		return mediaconvert.H264CodecLevelLevel3_2
+	case "4":
+		return mediaconvert.H264CodecLevelLevel4
+	case "4.1":
+		return mediaconvert.H264CodecLevelLevel4_1
+	case "4.2":
+		return mediaconvert.H264CodecLevelLevel4_2
+	case "5":
+		return mediaconvert.H264CodecLevelLevel5
+	case "5.1":
+		return mediaconvert.H264CodecLevelLevel5_1
+	case "5.2":
+		return mediaconvert.H264CodecLevelLevel5_2
+	default:
+		return mediaconvert.H264CodecLevelAuto
+	}
+}
+
+// codingModeFrom translates a channel count into the mediaconvert audio
+// coding mode. Unrecognized channel counts default to stereo.
+func codingModeFrom(channels string) string {
+	switch channels {
+	case "1":
+		return mediaconvert.AacCodingModeAdReceiverMix
+	case "6":
+		return mediaconvert.AacCodingModeCodingMode51
+	default:
+		return mediaconvert.AacCodingModeCodingMode20
+	}
+}
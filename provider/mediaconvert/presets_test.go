@@ -0,0 +1,96 @@
+package mediaconvert
+
+import (
+	"testing"
+
+	"github.com/NYTimes/video-transcoding-api/db"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/mediaconvert"
+)
+
+func TestPresetTranslatorTranslate(t *testing.T) {
+	preset := db.Preset{
+		Name:        "preset-720p",
+		Container:   "mp4",
+		RateControl: "vbr",
+		Video: db.VideoPreset{
+			Codec:        "h264",
+			Profile:      "high",
+			ProfileLevel: "4.1",
+			Bitrate:      "3500000",
+			GopSize:      "90",
+		},
+		Audio: db.AudioPreset{
+			Codec:    "aac",
+			Bitrate:  "128000",
+			Channels: "2",
+		},
+	}
+	settings, err := presetTranslator{}.translate(preset)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	h264 := settings.VideoDescription.CodecSettings.H264Settings
+	if aws.Int64Value(h264.Bitrate) != 3500000 {
+		t.Errorf("video bitrate = %d, want 3500000", aws.Int64Value(h264.Bitrate))
+	}
+	if aws.StringValue(h264.RateControlMode) != mediaconvert.H264RateControlModeVbr {
+		t.Errorf("rate control mode = %q, want %q", aws.StringValue(h264.RateControlMode), mediaconvert.H264RateControlModeVbr)
+	}
+	if aws.StringValue(h264.CodecLevel) != "LEVEL_4_1" {
+		t.Errorf("codec level = %q, want LEVEL_4_1", aws.StringValue(h264.CodecLevel))
+	}
+	aac := settings.AudioDescriptions[0].CodecSettings.AacSettings
+	if aws.Int64Value(aac.Bitrate) != 128000 {
+		t.Errorf("audio bitrate = %d, want 128000", aws.Int64Value(aac.Bitrate))
+	}
+	if aws.StringValue(aac.CodingMode) != mediaconvert.AacCodingModeCodingMode20 {
+		t.Errorf("coding mode = %q, want stereo", aws.StringValue(aac.CodingMode))
+	}
+	if aws.StringValue(settings.ContainerSettings.Container) != mediaconvert.ContainerTypeMp4 {
+		t.Errorf("container = %q, want MP4", aws.StringValue(settings.ContainerSettings.Container))
+	}
+}
+
+func TestPresetTranslatorTranslateUnsupportedVideoCodec(t *testing.T) {
+	preset := db.Preset{
+		Container: "mp4",
+		Video:     db.VideoPreset{Codec: "vp9", Bitrate: "1000"},
+		Audio:     db.AudioPreset{Codec: "aac", Bitrate: "1000"},
+	}
+	if _, err := (presetTranslator{}).translate(preset); err == nil {
+		t.Fatal("expected an error for an unsupported video codec")
+	}
+}
+
+func TestCodingModeFrom(t *testing.T) {
+	tests := []struct {
+		channels string
+		expected string
+	}{
+		{"1", mediaconvert.AacCodingModeAdReceiverMix},
+		{"6", mediaconvert.AacCodingModeCodingMode51},
+		{"2", mediaconvert.AacCodingModeCodingMode20},
+		{"", mediaconvert.AacCodingModeCodingMode20},
+	}
+	for _, test := range tests {
+		if got := codingModeFrom(test.channels); got != test.expected {
+			t.Errorf("codingModeFrom(%q) = %q, want %q", test.channels, got, test.expected)
+		}
+	}
+}
+
+func TestCodecLevelFrom(t *testing.T) {
+	if got := codecLevelFrom(""); got != mediaconvert.H264CodecLevelAuto {
+		t.Errorf("codecLevelFrom(\"\") = %q, want AUTO", got)
+	}
+	if got := codecLevelFrom("4.1"); got != "LEVEL_4_1" {
+		t.Errorf("codecLevelFrom(\"4.1\") = %q, want LEVEL_4_1", got)
+	}
+	if got := codecLevelFrom("4.1.2"); got != mediaconvert.H264CodecLevelAuto {
+		t.Errorf("codecLevelFrom(\"4.1.2\") = %q, want AUTO for an unrecognized level", got)
+	}
+	if got := codecLevelFrom("foo"); got != mediaconvert.H264CodecLevelAuto {
+		t.Errorf("codecLevelFrom(\"foo\") = %q, want AUTO for an unrecognized level", got)
+	}
+}
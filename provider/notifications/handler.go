@@ -0,0 +1,132 @@
+// Package notifications exposes an HTTP handler that consumes job state
+// notifications pushed by MediaConvert, so the API can serve job status
+// from the local database instead of polling the provider on every
+// request.
+//
+// MediaConvert jobs publish state changes to CloudWatch Events, which can
+// be routed to the SNS topic configured in config.MediaConvert.SNSTopicARN;
+// this handler is meant to be subscribed as that topic's HTTPS endpoint. It
+// does not understand the notifications Elemental Conductor posts to
+// config.ElementalConductor.NotificationURL; those arrive in a different
+// envelope and are not handled here.
+package notifications
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/NYTimes/video-transcoding-api/db"
+	"github.com/NYTimes/video-transcoding-api/provider/mediaconvert"
+)
+
+// snsEnvelope mirrors the SNS HTTP(S) notification envelope, including the
+// fields needed to verify its signature before acting on it. See:
+// https://docs.aws.amazon.com/sns/latest/dg/sns-message-and-json-formats.html
+type snsEnvelope struct {
+	Type             string `json:"Type"`
+	MessageID        string `json:"MessageId"`
+	TopicArn         string `json:"TopicArn"`
+	Subject          string `json:"Subject"`
+	Message          string `json:"Message"`
+	Timestamp        string `json:"Timestamp"`
+	SignatureVersion string `json:"SignatureVersion"`
+	Signature        string `json:"Signature"`
+	SigningCertURL   string `json:"SigningCertURL"`
+	SubscribeURL     string `json:"SubscribeURL"`
+	Token            string `json:"Token"`
+}
+
+// jobStateEvent is the payload of a MediaConvert "Job State Change"
+// CloudWatch Event, delivered as the SNS notification's Message.
+type jobStateEvent struct {
+	Detail struct {
+		JobID  string `json:"jobId"`
+		Status string `json:"status"`
+	} `json:"detail"`
+}
+
+// Handler receives SNS subscription confirmations and job state
+// notifications and updates the matching db.Job's cached status.
+type Handler struct {
+	Repository db.Repository
+}
+
+// NewHandler creates a Handler that persists status updates to repo.
+func NewHandler(repo db.Repository) *Handler {
+	return &Handler{Repository: repo}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var envelope snsEnvelope
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	// Nothing in envelope is trusted until its signature validates against
+	// a certificate fetched from an AWS-owned SigningCertURL: an
+	// unauthenticated POST here could otherwise direct us to GET an
+	// arbitrary SubscribeURL (SSRF) or spoof a job's status.
+	if err := envelope.verify(); err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+	switch envelope.Type {
+	case "SubscriptionConfirmation":
+		err = confirmSubscription(envelope.SubscribeURL)
+	case "Notification":
+		err = h.handleJobStateNotification(envelope.Message)
+	default:
+		err = fmt.Errorf("unsupported SNS message type %q", envelope.Type)
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// confirmSubscription follows the SubscribeURL SNS sends on topic
+// subscription, completing the handshake so future notifications are
+// delivered. The caller must have already verified the envelope carrying
+// this URL.
+func confirmSubscription(subscribeURL string) error {
+	if _, err := verifySNSHost(subscribeURL); err != nil {
+		return err
+	}
+	resp, err := http.Get(subscribeURL)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("error confirming SNS subscription: unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// handleJobStateNotification looks up the db.Job referenced by the event
+// and updates its cached status, mapped through the same provider.Status
+// values JobStatus serves so the two never disagree.
+func (h *Handler) handleJobStateNotification(message string) error {
+	var event jobStateEvent
+	if err := json.Unmarshal([]byte(message), &event); err != nil {
+		return err
+	}
+	job, err := h.Repository.GetJobByProviderJobID(event.Detail.JobID)
+	if err != nil {
+		return err
+	}
+	job.Status = string(mediaconvert.StatusMap(event.Detail.Status))
+	return h.Repository.SaveJob(job)
+}
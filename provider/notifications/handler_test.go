@@ -0,0 +1,75 @@
+package notifications
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/NYTimes/video-transcoding-api/db"
+)
+
+type fakeRepository struct {
+	jobs map[string]*db.Job
+}
+
+func (r *fakeRepository) GetJobByProviderJobID(providerJobID string) (*db.Job, error) {
+	job, ok := r.jobs[providerJobID]
+	if !ok {
+		return nil, fmt.Errorf("job %q not found", providerJobID)
+	}
+	return job, nil
+}
+
+func (r *fakeRepository) SaveJob(job *db.Job) error {
+	r.jobs[job.ProviderJobID] = job
+	return nil
+}
+
+func TestServeHTTPRejectsUnverifiedEnvelope(t *testing.T) {
+	repo := &fakeRepository{jobs: map[string]*db.Job{}}
+	handler := NewHandler(repo)
+	body, _ := json.Marshal(snsEnvelope{
+		Type:           "Notification",
+		Message:        `{"detail":{"jobId":"job-1","status":"COMPLETE"}}`,
+		SigningCertURL: "https://evil.example.com/cert.pem",
+	})
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("status = %d, want %d for an envelope with an untrusted SigningCertURL", rec.Code, http.StatusForbidden)
+	}
+}
+
+func TestServeHTTPRejectsNonPost(t *testing.T) {
+	handler := NewHandler(&fakeRepository{jobs: map[string]*db.Job{}})
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusMethodNotAllowed)
+	}
+}
+
+func TestHandleJobStateNotificationMapsStatus(t *testing.T) {
+	repo := &fakeRepository{jobs: map[string]*db.Job{
+		"job-1": {ProviderJobID: "job-1"},
+	}}
+	handler := NewHandler(repo)
+	err := handler.handleJobStateNotification(`{"detail":{"jobId":"job-1","status":"COMPLETE"}}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if repo.jobs["job-1"].Status != "finished" {
+		t.Errorf("job status = %q, want the mapped provider.Status \"finished\", not the raw MediaConvert status", repo.jobs["job-1"].Status)
+	}
+}
+
+func TestConfirmSubscriptionRejectsNonSNSHost(t *testing.T) {
+	if err := confirmSubscription("https://evil.example.com/subscribe"); err == nil {
+		t.Fatal("expected confirmSubscription to reject a non-SNS SubscribeURL host")
+	}
+}
@@ -0,0 +1,131 @@
+package notifications
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"regexp"
+)
+
+// snsHostPattern matches the AWS-owned hosts SNS uses for both the
+// SubscribeURL it asks us to call back and the SigningCertURL it asks us to
+// fetch the signing certificate from. Accepting any other host would let an
+// attacker point either URL at a host of their choosing.
+var snsHostPattern = regexp.MustCompile(`^sns\.[a-z0-9-]+\.amazonaws\.com(\.cn)?$`)
+
+// verifySNSHost checks that rawURL is an https URL on an AWS SNS host.
+func verifySNSHost(rawURL string) (*url.URL, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SNS URL %q: %s", rawURL, err)
+	}
+	if parsed.Scheme != "https" {
+		return nil, fmt.Errorf("invalid SNS URL %q: must use https", rawURL)
+	}
+	if !snsHostPattern.MatchString(parsed.Host) {
+		return nil, fmt.Errorf("invalid SNS URL %q: host %q is not an AWS SNS endpoint", rawURL, parsed.Host)
+	}
+	return parsed, nil
+}
+
+// verify checks that envelope was genuinely signed by SNS: its
+// SigningCertURL must point at an AWS SNS host, and the signature it
+// carries must validate against the certificate fetched from that URL.
+// This must pass before the envelope's SubscribeURL is called or its
+// Message is trusted to mutate job status.
+func (e *snsEnvelope) verify() error {
+	if _, err := verifySNSHost(e.SigningCertURL); err != nil {
+		return err
+	}
+	cert, err := fetchSigningCertificate(e.SigningCertURL)
+	if err != nil {
+		return err
+	}
+	signature, err := base64.StdEncoding.DecodeString(e.Signature)
+	if err != nil {
+		return fmt.Errorf("invalid SNS signature encoding: %s", err)
+	}
+	pubKey, ok := cert.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return fmt.Errorf("unexpected SNS signing certificate key type %T", cert.PublicKey)
+	}
+	hash, hashed, err := e.stringToSignDigest()
+	if err != nil {
+		return err
+	}
+	if err := rsa.VerifyPKCS1v15(pubKey, hash, hashed, signature); err != nil {
+		return fmt.Errorf("SNS signature verification failed: %s", err)
+	}
+	return nil
+}
+
+// fetchSigningCertificate downloads and parses the PEM certificate SNS
+// signed the envelope with. The caller must have already validated
+// certURL's host with verifySNSHost.
+func fetchSigningCertificate(certURL string) (*x509.Certificate, error) {
+	resp, err := http.Get(certURL)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching SNS signing certificate: %s", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error fetching SNS signing certificate: unexpected status %d", resp.StatusCode)
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading SNS signing certificate: %s", err)
+	}
+	block, _ := pem.Decode(body)
+	if block == nil {
+		return nil, fmt.Errorf("invalid SNS signing certificate: not PEM encoded")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+// stringToSignDigest builds the canonical string SNS signs for e and
+// returns its digest along with the hash algorithm matching e's
+// SignatureVersion ("1" is SHA1, "2" is SHA256).
+// See: https://docs.aws.amazon.com/sns/latest/dg/sns-verify-signature-of-message.html
+func (e *snsEnvelope) stringToSignDigest() (crypto.Hash, []byte, error) {
+	var fields [][2]string
+	switch e.Type {
+	case "Notification":
+		fields = [][2]string{{"Message", e.Message}, {"MessageId", e.MessageID}}
+		if e.Subject != "" {
+			fields = append(fields, [2]string{"Subject", e.Subject})
+		}
+		fields = append(fields, [2]string{"Timestamp", e.Timestamp}, [2]string{"TopicArn", e.TopicArn}, [2]string{"Type", e.Type})
+	case "SubscriptionConfirmation", "UnsubscribeConfirmation":
+		fields = [][2]string{
+			{"Message", e.Message},
+			{"MessageId", e.MessageID},
+			{"SubscribeURL", e.SubscribeURL},
+			{"Timestamp", e.Timestamp},
+			{"Token", e.Token},
+			{"TopicArn", e.TopicArn},
+			{"Type", e.Type},
+		}
+	default:
+		return 0, nil, fmt.Errorf("cannot build signable string for SNS message type %q", e.Type)
+	}
+	var stringToSign []byte
+	for _, field := range fields {
+		stringToSign = append(stringToSign, []byte(field[0]+"\n"+field[1]+"\n")...)
+	}
+	switch e.SignatureVersion {
+	case "2":
+		sum := sha256.Sum256(stringToSign)
+		return crypto.SHA256, sum[:], nil
+	default:
+		sum := sha1.Sum(stringToSign)
+		return crypto.SHA1, sum[:], nil
+	}
+}
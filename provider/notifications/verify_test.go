@@ -0,0 +1,83 @@
+package notifications
+
+import (
+	"crypto"
+	"crypto/sha1"
+	"crypto/sha256"
+	"testing"
+)
+
+func TestVerifySNSHost(t *testing.T) {
+	tests := []struct {
+		url     string
+		wantErr bool
+	}{
+		{"https://sns.us-east-1.amazonaws.com/SimpleNotificationService-abcd.pem", false},
+		{"https://sns.cn-north-1.amazonaws.com.cn/SimpleNotificationService-abcd.pem", false},
+		{"http://sns.us-east-1.amazonaws.com/cert.pem", true},  // not https
+		{"https://evil.example.com/sns.us-east-1.amazonaws.com", true}, // host mismatch
+		{"https://sns.us-east-1.amazonaws.com.evil.com/cert.pem", true},
+		{"not a url", true},
+	}
+	for _, test := range tests {
+		_, err := verifySNSHost(test.url)
+		if (err != nil) != test.wantErr {
+			t.Errorf("verifySNSHost(%q) error = %v, wantErr %v", test.url, err, test.wantErr)
+		}
+	}
+}
+
+func TestStringToSignDigestNotification(t *testing.T) {
+	envelope := &snsEnvelope{
+		Type:             "Notification",
+		Message:          `{"detail":{"jobId":"1","status":"COMPLETE"}}`,
+		MessageID:        "msg-id",
+		Timestamp:        "2020-01-01T00:00:00.000Z",
+		TopicArn:         "arn:aws:sns:us-east-1:123456789012:topic",
+		SignatureVersion: "1",
+	}
+	hash, digest, err := envelope.stringToSignDigest()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if hash != crypto.SHA1 {
+		t.Errorf("hash = %v, want SHA1 for SignatureVersion 1", hash)
+	}
+	stringToSign := "Message\n" + envelope.Message + "\n" +
+		"MessageId\n" + envelope.MessageID + "\n" +
+		"Timestamp\n" + envelope.Timestamp + "\n" +
+		"TopicArn\n" + envelope.TopicArn + "\n" +
+		"Type\n" + envelope.Type + "\n"
+	want := sha1.Sum([]byte(stringToSign))
+	if string(digest) != string(want[:]) {
+		t.Error("digest does not match the manually computed SHA1 sum of the expected signable fields")
+	}
+}
+
+func TestStringToSignDigestSignatureVersion2UsesSHA256(t *testing.T) {
+	envelope := &snsEnvelope{
+		Type:             "Notification",
+		Message:          "hello",
+		MessageID:        "msg-id",
+		Timestamp:        "2020-01-01T00:00:00.000Z",
+		TopicArn:         "arn:aws:sns:us-east-1:123456789012:topic",
+		SignatureVersion: "2",
+	}
+	hash, digest, err := envelope.stringToSignDigest()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if hash != crypto.SHA256 {
+		t.Errorf("hash = %v, want SHA256 for SignatureVersion 2", hash)
+	}
+	if len(digest) != sha256.Size {
+		t.Errorf("digest length = %d, want %d", len(digest), sha256.Size)
+	}
+}
+
+func TestStringToSignDigestUnsupportedType(t *testing.T) {
+	envelope := &snsEnvelope{Type: "UnknownType"}
+	if _, _, err := envelope.stringToSignDigest(); err == nil {
+		t.Fatal("expected an error for an unsupported SNS message type")
+	}
+}